@@ -0,0 +1,86 @@
+package systray
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"sync"
+)
+
+// iconHandle is an opaque reference to a decoded icon as understood by the
+// native layer (an HBITMAP on Windows, a GdkPixbuf on Linux, an NSImage on
+// macOS). Go code never dereferences it, only caches and passes it along.
+//
+// The per-platform code that actually renders icon/templateIcon bytes into
+// an iconHandle (SetMenuItemInfoW+CreateDIBSection on Windows, GtkImage/
+// AppIndicator on Linux, NSImage on macOS) is not yet present in this tree;
+// loadIconHandle is the extension point it should fill in.
+type iconHandle uintptr
+
+// maxCachedIcons bounds how many distinct icons the cache remembers.
+// Long-running apps that animate a status icon through many distinct
+// frames would otherwise grow the cache without limit. Eviction only drops
+// the cache entry; the evicted native handle is not released.
+const maxCachedIcons = 64
+
+type iconCacheEntry struct {
+	key    [sha1.Size]byte
+	handle iconHandle
+}
+
+var (
+	iconCacheLock sync.Mutex
+	// iconCacheList orders entries by recency, most-recently-used at the
+	// front, so the least-recently-used one can be evicted in O(1).
+	iconCacheList = list.New()
+	iconCacheMap  = make(map[[sha1.Size]byte]*list.Element)
+)
+
+// cachedIconHandle returns the native handle for icon, decoding it via
+// loadIconHandle only the first time a given set of bytes is seen, and
+// evicting the least-recently-used handle once the cache grows past
+// maxCachedIcons.
+func cachedIconHandle(icon []byte) iconHandle {
+	key := sha1.Sum(icon)
+
+	iconCacheLock.Lock()
+	defer iconCacheLock.Unlock()
+
+	if el, ok := iconCacheMap[key]; ok {
+		iconCacheList.MoveToFront(el)
+		return el.Value.(*iconCacheEntry).handle
+	}
+
+	h := loadIconHandle(icon)
+	el := iconCacheList.PushFront(&iconCacheEntry{key: key, handle: h})
+	iconCacheMap[key] = el
+
+	if iconCacheList.Len() > maxCachedIcons {
+		oldest := iconCacheList.Back()
+		iconCacheList.Remove(oldest)
+		delete(iconCacheMap, oldest.Value.(*iconCacheEntry).key)
+	}
+
+	return h
+}
+
+// SetIcon sets the icon of a menu item. iconBytes must be the content of a
+// PNG file.
+func (item *MenuItem) SetIcon(iconBytes []byte) {
+	item.icon = iconBytes
+	item.iconHandle = cachedIconHandle(iconBytes)
+	item.templateIcon = nil
+	item.templateIconHandle = 0
+	item.update()
+}
+
+// SetTemplateIcon sets the icon of a menu item to iconBytes, but renders it
+// as regularIconBytes on platforms that have no notion of a template
+// image that auto-adapts to a light/dark menu theme. Both must be the
+// content of a PNG file.
+func (item *MenuItem) SetTemplateIcon(iconBytes []byte, regularIconBytes []byte) {
+	item.icon = regularIconBytes
+	item.iconHandle = cachedIconHandle(regularIconBytes)
+	item.templateIcon = iconBytes
+	item.templateIconHandle = cachedIconHandle(iconBytes)
+	item.update()
+}
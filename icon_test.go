@@ -0,0 +1,36 @@
+//go:build systray_test
+
+package systray
+
+import "testing"
+
+func TestCachedIconHandleReusesSameBytes(t *testing.T) {
+	icon := []byte("icon-a")
+	h1 := cachedIconHandle(icon)
+	h2 := cachedIconHandle(append([]byte(nil), icon...))
+	if h1 != h2 {
+		t.Fatalf("expected identical icon bytes to reuse the cached handle, got %v and %v", h1, h2)
+	}
+}
+
+func TestCachedIconHandleEvictsLeastRecentlyUsed(t *testing.T) {
+	iconCacheLock.Lock()
+	iconCacheList.Init()
+	for k := range iconCacheMap {
+		delete(iconCacheMap, k)
+	}
+	iconCacheLock.Unlock()
+
+	first := cachedIconHandle([]byte("icon-0"))
+	for i := 1; i <= maxCachedIcons; i++ {
+		cachedIconHandle([]byte{byte(i)})
+	}
+
+	// icon-0 was least recently used once maxCachedIcons other distinct
+	// icons had been cached after it, so it should have been evicted and
+	// re-decoding it now must produce a fresh handle.
+	again := cachedIconHandle([]byte("icon-0"))
+	if again == first {
+		t.Fatalf("expected icon-0's handle to be evicted and re-decoded, still got %v", again)
+	}
+}
@@ -0,0 +1,136 @@
+package systray
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// NotifyIcon selects the glyph shown next to a notification's title.
+// Use WithNotifyCustomIcon to supply your own PNG; it selects NotifyIconCustom.
+type NotifyIcon byte
+
+const (
+	NotifyIconInfo NotifyIcon = iota
+	NotifyIconWarning
+	NotifyIconError
+	NotifyIconCustom
+)
+
+// Notification represents a single balloon/toast raised via Notify.
+// ClickedCh fires at most once, when the user activates the notification;
+// it is never closed, so callers should select on it alongside other
+// channels rather than ranging over it. Once a notification is activated
+// or dismissed (including by timeout) it stops being tracked, so a
+// long-running app that calls Notify repeatedly doesn't accumulate state
+// for notifications nobody ever acted on.
+type Notification struct {
+	ClickedCh chan struct{}
+
+	id int32
+}
+
+type notifyOptions struct {
+	icon       NotifyIcon
+	customIcon []byte
+	// timeoutMs is -1 unless WithNotifyTimeout was given, which leaves the
+	// display duration to the platform default.
+	timeoutMs int
+}
+
+// NotifyOption configures a Notify call. See WithNotifyIcon and
+// WithNotifyTimeout.
+type NotifyOption func(*notifyOptions)
+
+// WithNotifyIcon selects one of the built-in info/warning/error glyphs for
+// the notification.
+func WithNotifyIcon(icon NotifyIcon) NotifyOption {
+	return func(o *notifyOptions) {
+		o.icon = icon
+	}
+}
+
+// WithNotifyCustomIcon sets a custom PNG to show instead of a built-in
+// glyph. It implies NotifyIconCustom.
+func WithNotifyCustomIcon(png []byte) NotifyOption {
+	return func(o *notifyOptions) {
+		o.icon = NotifyIconCustom
+		o.customIcon = png
+	}
+}
+
+// WithNotifyTimeout overrides the platform default display duration, in
+// milliseconds. A timeout of 0 leaves the notification up until the user
+// dismisses it, where the platform supports that.
+func WithNotifyTimeout(timeoutMs int) NotifyOption {
+	return func(o *notifyOptions) {
+		o.timeoutMs = timeoutMs
+	}
+}
+
+var (
+	notifications     = make(map[int32]*Notification)
+	notificationsLock sync.RWMutex
+
+	currentNotifyID = int32(-1)
+)
+
+// Notify raises a native balloon/toast notification anchored to the tray
+// icon. It can be safely invoked from any goroutine, including before
+// Run's onReady callback fires.
+//
+// The returned Notification's ClickedCh fires when the user activates the
+// notification; callers that don't care can discard it.
+//
+// notify is expected to call systrayNotificationClicked when the user
+// activates the notification, and systrayNotificationDismissed when it is
+// dismissed or times out unclicked; this keeps the notifications map from
+// growing without bound. The per-platform implementations of notify
+// (Shell_NotifyIcon NIF_INFO on Windows, NSUserNotification/
+// UNUserNotificationCenter on macOS, libnotify/DBus on Linux) are not yet
+// present in this tree.
+func Notify(title, body string, opts ...NotifyOption) *Notification {
+	o := notifyOptions{icon: NotifyIconInfo, timeoutMs: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	id := atomic.AddInt32(&currentNotifyID, 1)
+	n := &Notification{
+		ClickedCh: make(chan struct{}),
+		id:        id,
+	}
+
+	notificationsLock.Lock()
+	notifications[id] = n
+	notificationsLock.Unlock()
+
+	notify(id, title, body, o)
+	return n
+}
+
+func systrayNotificationClicked(id int32) {
+	n := forgetNotification(id)
+	if n == nil {
+		return
+	}
+	select {
+	case n.ClickedCh <- struct{}{}:
+	// in case no one waiting for the channel
+	default:
+	}
+}
+
+// systrayNotificationDismissed is called by the native layer when a
+// notification is dismissed or times out without being clicked, so it can
+// stop being tracked.
+func systrayNotificationDismissed(id int32) {
+	forgetNotification(id)
+}
+
+func forgetNotification(id int32) *Notification {
+	notificationsLock.Lock()
+	defer notificationsLock.Unlock()
+	n := notifications[id]
+	delete(notifications, id)
+	return n
+}
@@ -36,20 +36,52 @@ type MenuItem struct {
 
 	// id uniquely identify a menu item, not supposed to be modified
 	id int32
-	// menuId uniquely identify a menu the menu item belogns to
+	// menuId is the id of the native submenu container this item owns, so
+	// children can be attached to it; only meaningful when hasSubmenu is
+	// true, since 0 is itself a valid id (nextID hands it out first)
 	menuId int32
+	// hasSubmenu reports whether menuId identifies a real submenu
+	// container, so a submenu container created with menuId 0 isn't
+	// mistaken for a leaf item
+	hasSubmenu bool
+	// parent is the submenu this item belongs to, or nil for a top-level
+	// item. Following parent chains lets the native layers render submenus
+	// of arbitrary nesting depth (a submenu of a submenu, and so on).
+	parent *MenuItem
 	// title is the text shown on menu item
 	title string
 	// tooltip is the text shown when pointing to menu item
 	tooltip string
+	// icon is the PNG content shown next to the title, if any
+	icon []byte
+	// iconHandle is the cached native handle decoded from icon
+	iconHandle iconHandle
+	// templateIcon is the PNG content rendered as a template image on
+	// platforms/themes that support it, falling back to icon otherwise
+	templateIcon []byte
+	// templateIconHandle is the cached native handle decoded from templateIcon
+	templateIconHandle iconHandle
+	// removed is set once Remove has torn this item down, guarding against
+	// a repeat Remove double-closing ClickedCh or double-recycling id
+	removed bool
 	// disabled menu item is grayed out and has no effect when clicked
 	disabled bool
 	// checked menu item has a tick before the title
-	checked       bool
-	checkable     bool
-	isSeparator   bool
-	isSubmenu     bool
-	isSubmenuItem bool
+	checked     bool
+	checkable   bool
+	isSeparator bool
+}
+
+// isSubmenu reports whether the item owns a native submenu container that
+// other items can be attached to.
+func (item *MenuItem) isSubmenu() bool {
+	return item.hasSubmenu
+}
+
+// isSubmenuItem reports whether the item lives inside a submenu, as
+// opposed to the top-level tray menu.
+func (item *MenuItem) isSubmenuItem() bool {
+	return item.parent != nil
 }
 
 var (
@@ -61,8 +93,23 @@ var (
 	menuItemsLock sync.RWMutex
 
 	currentID = int32(-1)
+	freeIDs   []int32
 )
 
+// nextID returns an id for a new menu item, recycling one freed by Remove
+// if available so currentID doesn't overflow in long-running daemons that
+// rebuild their menu often.
+func nextID() int32 {
+	menuItemsLock.Lock()
+	defer menuItemsLock.Unlock()
+	if n := len(freeIDs); n > 0 {
+		id := freeIDs[n-1]
+		freeIDs = freeIDs[:n-1]
+		return id
+	}
+	return atomic.AddInt32(&currentID, 1)
+}
+
 // Run initializes GUI and starts the event loop, then invokes the onReady
 // callback.
 // It blocks until systray.Quit() is called.
@@ -107,7 +154,7 @@ func Quit() {
 //
 // It can be safely invoked from different goroutines.
 func AddMenuItem(title string, tooltip string, flagsArr ...byte) *MenuItem {
-	id := atomic.AddInt32(&currentID, 1)
+	id := nextID()
 
 	flags := byte(0)
 	if len(flagsArr) > 0 {
@@ -122,34 +169,58 @@ func AddMenuItem(title string, tooltip string, flagsArr ...byte) *MenuItem {
 	}
 
 	item := &MenuItem{
-		ClickedCh:     make(chan struct{}),
-		id:            id,
-		title:         title,
-		tooltip:       tooltip,
-		isSubmenuItem: false,
-		isSubmenu:     false,
-		isSeparator:   ItemSeparator&flags != 0,
-		checkable:     ItemCheckable&flags != 0,
-		checked:       ItemChecked&flags != 0,
-		disabled:      ItemDisabled&flags != 0,
+		ClickedCh:   make(chan struct{}),
+		id:          id,
+		title:       title,
+		tooltip:     tooltip,
+		isSeparator: ItemSeparator&flags != 0,
+		checkable:   ItemCheckable&flags != 0,
+		checked:     ItemChecked&flags != 0,
+		disabled:    ItemDisabled&flags != 0,
 	}
 
 	item.update()
 	return item
 }
 
+// AddMenuItemCheckbox adds a checkable menu item with designated title and
+// tooltip, returning the item used to access it.
+func AddMenuItemCheckbox(title, tooltip string, checked bool) *MenuItem {
+	flags := ItemCheckable
+	if checked {
+		flags |= ItemChecked
+	}
+	return AddMenuItem(title, tooltip, flags)
+}
+
 // AddSubMenu adds a sub menu to the systray and
 // and returns an id to access to accesss the menu
 func AddSubMenu(title string) *MenuItem {
-	subMenuId := atomic.AddInt32(&currentID, 1)
+	return newSubMenu(title, nil)
+}
+
+// AddSubMenu adds a nested sub menu under sitem, so submenus of arbitrary
+// depth can be built (e.g. top -> middle -> bottom).
+func (sitem *MenuItem) AddSubMenu(title string) *MenuItem {
+	return newSubMenu(title, sitem)
+}
+
+// newSubMenu is shared by the top-level and nested AddSubMenu. Rendering a
+// parent chain more than one level deep (SubMenuTop -> SubMenuMiddle ->
+// SubMenuBottom) is the native layer's responsibility; createSubMenu/
+// addSubmenuToTray for systray_darwin.m/systray_linux.c/systray_windows.go
+// are not yet present in this tree.
+func newSubMenu(title string, parent *MenuItem) *MenuItem {
+	subMenuId := nextID()
 	createSubMenu(subMenuId)
 
 	item := &MenuItem{
-		ClickedCh:     make(chan struct{}),
-		id:            atomic.AddInt32(&currentID, 1),
-		title:         title,
-		menuId:        subMenuId,
-		isSubmenuItem: false,
+		ClickedCh:  make(chan struct{}),
+		id:         nextID(),
+		title:      title,
+		menuId:     subMenuId,
+		hasSubmenu: true,
+		parent:     parent,
 	}
 	if atomic.LoadInt64(&hasStarted) == 1 {
 		addSubmenuToTray(item)
@@ -168,25 +239,33 @@ func (sitem *MenuItem) AddSubMenuItem(title, tooltip string, flags byte) *MenuIt
 	}
 
 	item := &MenuItem{
-		ClickedCh:     make(chan struct{}),
-		id:            atomic.AddInt32(&currentID, 1),
-		title:         title,
-		tooltip:       tooltip,
-		menuId:        sitem.menuId,
-		isSeparator:   ItemSeparator&flags != 0,
-		isSubmenuItem: true,
-		checkable:     ItemCheckable&flags != 0,
-		checked:       ItemChecked&flags != 0,
-		disabled:      ItemDisabled&flags != 0,
+		ClickedCh:   make(chan struct{}),
+		id:          nextID(),
+		title:       title,
+		tooltip:     tooltip,
+		parent:      sitem,
+		isSeparator: ItemSeparator&flags != 0,
+		checkable:   ItemCheckable&flags != 0,
+		checked:     ItemChecked&flags != 0,
+		disabled:    ItemDisabled&flags != 0,
 	}
 
 	item.update()
 	return item
 }
 
+// AddSubMenuItemCheckbox adds a checkable submenu item and returns it.
+func (sitem *MenuItem) AddSubMenuItemCheckbox(title, tooltip string, checked bool) *MenuItem {
+	flags := ItemCheckable
+	if checked {
+		flags |= ItemChecked
+	}
+	return sitem.AddSubMenuItem(title, tooltip, flags)
+}
+
 // AddSeparator adds a separator bar to the menu
 func AddSeparator() {
-	addSeparator(atomic.AddInt32(&currentID, 1))
+	addSeparator(nextID())
 }
 
 // SetTitle set the text to display on a menu item
@@ -253,10 +332,99 @@ func (item *MenuItem) update() {
 	addOrUpdateMenuItem(item)
 }
 
+// Remove removes the menu item from the tray and closes its ClickedCh. Any
+// children added via AddSubMenuItem/AddSubMenuItemCheckbox/AddSubMenu are
+// removed recursively, so submenus don't leave orphaned entries behind.
+// The item (and its children) must not be used afterwards; calling Remove
+// more than once, or concurrently with a ResetMenu, is a safe no-op beyond
+// the first teardown of a given item.
+func (item *MenuItem) Remove() {
+	children, ok := removeLocked(item)
+	if !ok {
+		return
+	}
+
+	for _, child := range children {
+		child.Remove()
+	}
+
+	removeMenuItem(item)
+}
+
+// removeLocked flips item.removed, drops it from menuItems, recycles its
+// id and closes its ClickedCh, all inside one menuItemsLock critical
+// section shared with ResetMenu's teardown loop and with
+// systrayMenuItemSelected's send. That's what rules out the two races a
+// naive "check removed, then later close" sequence allows: a concurrent
+// Remove/ResetMenu of the same item double-closing ClickedCh, and a
+// concurrent native click sending on a channel that's mid-close. It
+// returns the item's direct children, which the caller removes afterwards
+// (outside the lock, since each child's own removeLocked call needs it
+// too), and false if the item had already been torn down.
+func removeLocked(item *MenuItem) ([]*MenuItem, bool) {
+	menuItemsLock.Lock()
+	defer menuItemsLock.Unlock()
+
+	if item.removed {
+		return nil, false
+	}
+	item.removed = true
+
+	var children []*MenuItem
+	for _, other := range menuItems {
+		if other.parent == item {
+			children = append(children, other)
+		}
+	}
+
+	delete(menuItems, item.id)
+	freeIDs = append(freeIDs, item.id)
+	close(item.ClickedCh)
+
+	return children, true
+}
+
+// ResetMenu removes every menu item added so far, letting the caller
+// rebuild the menu from scratch. Unlike Remove, it does not recycle ids:
+// currentID and any pending freeIDs are reset so the next item starts a
+// fresh sequence.
+//
+// The native removeMenuItem/resetMenu shims for systray_darwin.m/
+// systray_linux.c/systray_windows.go that actually tear down the platform
+// menu are not yet present in this tree; Remove and ResetMenu only update
+// systray's own bookkeeping until those land.
+func ResetMenu() {
+	menuItemsLock.Lock()
+	for _, item := range menuItems {
+		if !item.removed {
+			item.removed = true
+			close(item.ClickedCh)
+		}
+	}
+	menuItems = make(map[int32]*MenuItem)
+	freeIDs = nil
+	atomic.StoreInt32(&currentID, -1)
+	menuItemsLock.Unlock()
+
+	resetMenu()
+}
+
+// systrayMenuItemSelected holds menuItemsLock for the whole lookup-and-send,
+// the same lock Remove/ResetMenu hold for their whole flip-and-close. That
+// makes the two mutually exclusive: either this runs first and the send
+// completes before any concurrent close can start, or a close completes
+// (and deletes id from menuItems) before this ever looks id up — so the
+// send below can never race a close of the same channel.
 func systrayMenuItemSelected(id int32) {
 	menuItemsLock.RLock()
+	defer menuItemsLock.RUnlock()
+
 	item := menuItems[id]
-	menuItemsLock.RUnlock()
+	if item == nil {
+		// the item was removed (or its id never registered) before this
+		// native click was delivered; drop it rather than panic
+		return
+	}
 	select {
 	case item.ClickedCh <- struct{}{}:
 	// in case no one waiting for the channel
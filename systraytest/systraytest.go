@@ -0,0 +1,75 @@
+// Package systraytest is a headless test harness for code built on top of
+// github.com/getlantern/systray. Build and run your tests with
+// `-tags systray_test` so the systray package compiles against its
+// in-memory backend instead of a real platform one.
+package systraytest
+
+import (
+	"testing"
+
+	"github.com/getlantern/systray"
+)
+
+// MenuItemState is a read-only snapshot of a single menu item. ParentID is
+// the ID of the submenu it lives in (0 for a top-level item); ContainerID
+// is the ID of the native submenu container it owns when HasSubmenu is
+// true (0 otherwise) — so a test can reassemble the nesting tree a chain
+// of AddSubMenu/AddSubMenuItem calls built.
+type MenuItemState struct {
+	ID          int32
+	ParentID    int32
+	ContainerID int32
+	HasSubmenu  bool
+	Title       string
+	Tooltip     string
+	Checked     bool
+	Checkable   bool
+	Disabled    bool
+	Separator   bool
+	HasIcon     bool
+}
+
+// SimulateClick pushes a synthetic click into item's ClickedCh, as if a
+// user had activated it in the native tray.
+func SimulateClick(item *systray.MenuItem) {
+	systray.TestSimulateClick(item)
+}
+
+// Snapshot returns the current state of every menu item in the menu, as
+// captured by the headless backend's addOrUpdateMenuItem calls. Items taken
+// out by Remove or ResetMenu, and separators added with AddSeparator, are
+// not included.
+func Snapshot() []MenuItemState {
+	native := systray.TestSnapshot()
+	out := make([]MenuItemState, len(native))
+	for i, s := range native {
+		out[i] = MenuItemState(s)
+	}
+	return out
+}
+
+// TestMain runs onReady and onExit against the headless backend, blocking
+// until onReady has returned before running m, then quitting systray and
+// waiting for onExit to return. Use it from a package's own
+// TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(systraytest.TestMain(m, onReady, onExit))
+//	}
+func TestMain(m *testing.M, onReady func(), onExit func()) int {
+	ready := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		systray.Run(func() {
+			onReady()
+			close(ready)
+		}, onExit)
+		close(done)
+	}()
+	<-ready
+
+	code := m.Run()
+	systray.Quit()
+	<-done
+	return code
+}
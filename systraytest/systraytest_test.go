@@ -0,0 +1,81 @@
+//go:build systray_test
+
+package systraytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getlantern/systray"
+)
+
+func byTitle(t *testing.T, snap []MenuItemState) map[string]MenuItemState {
+	t.Helper()
+	out := make(map[string]MenuItemState, len(snap))
+	for _, s := range snap {
+		out[s.Title] = s
+	}
+	return out
+}
+
+func TestSimulateClickAndSnapshot(t *testing.T) {
+	systray.ResetMenu()
+
+	item := systray.AddMenuItem("Exit", "quit the app")
+
+	go SimulateClick(item)
+
+	select {
+	case <-item.ClickedCh:
+	case <-time.After(time.Second):
+		t.Fatal("SimulateClick never delivered on ClickedCh")
+	}
+
+	states := byTitle(t, Snapshot())
+	if _, ok := states["Exit"]; !ok {
+		t.Fatalf("expected Snapshot to contain the added item, got %+v", states)
+	}
+}
+
+func TestRemoveDropsItemAndRecyclesID(t *testing.T) {
+	systray.ResetMenu()
+
+	first := systray.AddMenuItem("First", "")
+	firstID := byTitle(t, Snapshot())["First"].ID
+
+	first.Remove()
+
+	if _, ok := byTitle(t, Snapshot())["First"]; ok {
+		t.Fatal("expected Remove to drop the item from the snapshot")
+	}
+
+	systray.AddMenuItem("Second", "")
+	secondID := byTitle(t, Snapshot())["Second"].ID
+	if secondID != firstID {
+		t.Fatalf("expected the removed id %d to be recycled, got %d", firstID, secondID)
+	}
+}
+
+func TestNestedSubMenuParentChain(t *testing.T) {
+	systray.ResetMenu()
+
+	top := systray.AddSubMenu("Top")
+	middle := top.AddSubMenu("Middle")
+	middle.AddSubMenuItemCheckbox("Bottom", "", false)
+
+	states := byTitle(t, Snapshot())
+	topState, middleState, bottomState := states["Top"], states["Middle"], states["Bottom"]
+
+	if !topState.HasSubmenu {
+		t.Fatalf("Top should own a submenu container: %+v", topState)
+	}
+	if middleState.ParentID != topState.ID {
+		t.Fatalf("Middle's parent should be Top: middle=%+v top=%+v", middleState, topState)
+	}
+	if bottomState.ParentID != middleState.ID {
+		t.Fatalf("Bottom's parent should be Middle: bottom=%+v middle=%+v", bottomState, middleState)
+	}
+	if !bottomState.Checkable {
+		t.Fatalf("Bottom should be checkable: %+v", bottomState)
+	}
+}
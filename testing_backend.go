@@ -0,0 +1,130 @@
+//go:build systray_test
+
+// This file provides systray's headless backend, used in place of a real
+// platform's systray_*.go/.m/.c when the systray_test build tag is set. It
+// backs the github.com/getlantern/systray/systraytest package so consumers
+// can exercise their tray logic in `go test` without a display server.
+package systray
+
+import "sync"
+
+// TestMenuItemState is a read-only snapshot of a single menu item, as
+// captured by the headless backend. Exported for systraytest; not part of
+// the public systray API.
+type TestMenuItemState struct {
+	ID          int32
+	ParentID    int32
+	ContainerID int32
+	HasSubmenu  bool
+	Title       string
+	Tooltip     string
+	Checked     bool
+	Checkable   bool
+	Disabled    bool
+	Separator   bool
+	HasIcon     bool
+}
+
+var (
+	testModelLock sync.RWMutex
+	testModel     = make(map[int32]TestMenuItemState)
+
+	quitCh = make(chan struct{})
+
+	iconHandleLock   sync.Mutex
+	nextIconHandleID = iconHandle(1)
+)
+
+func nativeLoop() {
+	systrayReady()
+	<-quitCh
+	systrayExit()
+}
+
+func quit() {
+	close(quitCh)
+}
+
+func createSubMenu(id int32) {}
+
+func addSubmenuToTray(item *MenuItem) {}
+
+func addOrUpdateMenuItem(item *MenuItem) {
+	parentID := int32(0)
+	if item.isSubmenuItem() {
+		parentID = item.parent.id
+	}
+
+	containerID := int32(0)
+	if item.isSubmenu() {
+		containerID = item.menuId
+	}
+
+	testModelLock.Lock()
+	defer testModelLock.Unlock()
+	testModel[item.id] = TestMenuItemState{
+		ID:          item.id,
+		ParentID:    parentID,
+		ContainerID: containerID,
+		HasSubmenu:  item.isSubmenu(),
+		Title:       item.title,
+		Tooltip:     item.tooltip,
+		Checked:     item.checked,
+		Checkable:   item.checkable,
+		Disabled:    item.disabled,
+		Separator:   item.isSeparator,
+		HasIcon:     item.iconHandle != 0 || item.templateIconHandle != 0,
+	}
+}
+
+func hideMenuItem(item *MenuItem) {}
+
+func showMenuItem(item *MenuItem) {}
+
+func addSeparator(id int32) {}
+
+func removeMenuItem(item *MenuItem) {
+	testModelLock.Lock()
+	defer testModelLock.Unlock()
+	delete(testModel, item.id)
+}
+
+func resetMenu() {
+	testModelLock.Lock()
+	defer testModelLock.Unlock()
+	testModel = make(map[int32]TestMenuItemState)
+}
+
+func notify(id int32, title, body string, o notifyOptions) {}
+
+// loadIconHandle hands out a distinct, monotonically increasing handle per
+// call, so cachedIconHandle's reuse-by-content and eviction behavior is
+// observable: two calls for the same bytes return the same handle, two
+// calls for different bytes never do.
+func loadIconHandle(icon []byte) iconHandle {
+	iconHandleLock.Lock()
+	defer iconHandleLock.Unlock()
+	h := nextIconHandleID
+	nextIconHandleID++
+	return h
+}
+
+// TestSimulateClick pushes a synthetic click into item's ClickedCh, as a
+// native callback would. Exported for systraytest; not part of the public
+// systray API.
+func TestSimulateClick(item *MenuItem) {
+	systrayMenuItemSelected(item.id)
+}
+
+// TestSnapshot returns the current state of every menu item in the menu;
+// items taken out by Remove or ResetMenu are not included.
+// Exported for systraytest; not part of the public systray API.
+func TestSnapshot() []TestMenuItemState {
+	testModelLock.RLock()
+	defer testModelLock.RUnlock()
+	out := make([]TestMenuItemState, 0, len(testModel))
+	for _, state := range testModel {
+		out = append(out, state)
+	}
+	return out
+}